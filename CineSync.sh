@@ -9,6 +9,7 @@ SCRIPTS_FOLDER="Scripts"
 BROKEN_LINK_FOLDER="BrokenLinkVault"
 MONITOR_SCRIPT="$SCRIPTS_FOLDER/service_manager.sh"
 ENV_FILE=".env"
+IGNORE_FILE="ignore_patterns.txt"
 
 # Function to check if a directory is valid
 is_valid_directory() {
@@ -243,6 +244,97 @@ execute_vault_scan() {
     fi
 }
 
+# Function to convert the existing library to the LINK_MODE set in .env
+convert_link_mode() {
+    script_path="$SCRIPTS_FOLDER/convert_links.sh"
+    if [[ -e "$script_path" ]]; then
+        if [[ $(uname -s) == "Linux" ]]; then
+            sudo bash "$script_path"
+        else
+            bash "$script_path"
+        fi
+        read -p "Conversion completed. Press Enter to return to the main menu..."
+    else
+        print_color "Error: The convert_links.sh script does not exist." "red"
+        read -p "Press Enter to return to the main menu..."
+    fi
+}
+
+# Function to manage source ignore patterns
+configure_ignore_patterns() {
+    # Create the ignore patterns file if it doesn't exist
+    touch "$IGNORE_FILE"
+
+    while true; do
+        clear_screen
+        print_banner
+        echo -e "\nIgnore Patterns:"
+        echo "1) Add Pattern"
+        echo "2) Remove Pattern"
+        echo "3) Show Current Patterns"
+        echo "4) Back to Main Menu"
+        read -p "Select an option: " choice
+
+        case $choice in
+            1)
+                echo -e "\nPatterns are shell globs matched case-insensitively against file and folder names (e.g. *sample*, *trailer*)."
+                echo "Prefix a pattern with re: to use a regular expression instead (e.g. re:^extras?$)."
+                read -rp "Enter pattern to add: " pattern
+
+                if [[ -n "$pattern" ]]; then
+                    if grep -qFx -- "$pattern" "$IGNORE_FILE"; then
+                        print_color "Pattern already exists." "yellow"
+                    else
+                        echo "$pattern" >> "$IGNORE_FILE"
+                        print_color "Pattern added successfully." "green"
+                    fi
+                else
+                    print_color "Invalid pattern. Please enter a non-empty pattern." "red"
+                fi
+                read -rp "Press Enter to continue..."
+                ;;
+            2)
+                if [[ ! -s "$IGNORE_FILE" ]]; then
+                    print_color "No patterns available." "yellow"
+                    read -p "Press Enter to continue..."
+                    continue
+                fi
+
+                echo -e "\nCurrent Patterns:"
+                cat -n "$IGNORE_FILE"
+
+                read -p "Select a pattern to remove (enter number): " option
+                if [[ "$option" =~ ^[0-9]+$ && "$option" -gt 0 && "$option" -le $(wc -l < "$IGNORE_FILE") ]]; then
+                    sed -i "${option}d" "$IGNORE_FILE"
+                    print_color "Pattern removed successfully." "green"
+                else
+                    print_color "Invalid option. Please select a valid pattern." "red"
+                fi
+                read -p "Press Enter to continue..."
+                ;;
+            3)
+                if [[ ! -s "$IGNORE_FILE" ]]; then
+                    print_color "No patterns available." "yellow"
+                else
+                    echo -e "\nCurrent Patterns:"
+                    cat -n "$IGNORE_FILE"
+                    if [[ -s "logs/ignored_files.log" ]]; then
+                        echo -e "\nIgnored files so far: $(wc -l < "logs/ignored_files.log")"
+                    fi
+                fi
+                read -rp "Press Enter to continue..."
+                ;;
+            4)
+                break
+                ;;
+            *)
+                print_color "Invalid option. Please select again." "red"
+                read -p "Press Enter to continue..."
+                ;;
+        esac
+    done
+}
+
 # Function to run renamer Script
 run_rename_script() {
     script_path="$SCRIPTS_FOLDER/tmdb_renamer.py"
@@ -274,7 +366,9 @@ main() {
         echo "3) Real-Time Monitoring"
         echo "4) Remove Broken Symlinks"
         echo "5) TMDB Renamer"
-        echo "6) Exit"
+        echo "6) Ignore Patterns"
+        echo "7) Convert Library Link Mode"
+        echo "8) Exit"
         read -p "Select an option: " choice
 
         case $choice in
@@ -294,6 +388,12 @@ main() {
                 run_rename_script
                 ;;
             6)
+                configure_ignore_patterns
+                ;;
+            7)
+                convert_link_mode
+                ;;
+            8)
                 print_color "Exiting..." "green"
                 break
                 ;;
@@ -7,6 +7,15 @@ SCRIPT_DIR=$(dirname "$(realpath "$0" | sed 's/\\/\//g')")
 BROKEN_LINKS_FOLDER="$SCRIPT_DIR/../BrokenLinkVault"
 CONFIG_FILE="$BROKEN_LINKS_FOLDER/broken_links_config.txt"
 LOGS_FOLDER="logs"
+ENV_FILE="$SCRIPT_DIR/../.env"
+
+# Load the symlink path translation rules, so links rewritten for another machine are checked against their local source
+if [ -f "$ENV_FILE" ]; then
+    source "$SCRIPT_DIR/load_env.sh"
+    load_env_file "$ENV_FILE"
+fi
+symlink_path_map="$SYMLINK_PATH_MAP"
+source "$SCRIPT_DIR/path_translation.sh"
 
 # Create the Broken_links folder if it doesn't exist
 mkdir -p "$BROKEN_LINKS_FOLDER"
@@ -27,16 +36,36 @@ if [ -f "$CONFIG_FILE" ]; then
     for directory in "${directories[@]}"; do
         cd "$directory" || { echo "Failed to change directory to $directory"; continue; }
 
-        # Find and display broken symlinks, then save to a log file
-        broken_links=$(find . -type l -xtype l)
-        if [ -n "$broken_links" ]; then
+        # Find broken symlinks, skipping translated links whose local source still exists
+        broken_links=()
+        while IFS= read -r -d '' link && IFS= read -r -d '' target; do
+            if translate_symlink_target "$target" "reverse" && [ -e "$translated_path" ]; then
+                continue
+            fi
+            broken_links+=("$link")
+        done < <(find . -type l -xtype l -printf '%p\0%l\0')
+
+        # .strm files are broken when the local source path they hold no longer exists
+        while IFS= read -r -d '' strm_file; do
+            IFS= read -r target < "$strm_file"
+            target="${target%$'\r'}"
+            if [[ "$target" == *"://"* ]]; then
+                continue
+            fi
+            translate_symlink_target "$target" "reverse"
+            if [ ! -e "$translated_path" ]; then
+                broken_links+=("$strm_file")
+            fi
+        done < <(find . -type f -name "*.strm" -print0)
+
+        if [ ${#broken_links[@]} -gt 0 ]; then
             log_file="$BROKEN_LINKS_FOLDER/$LOGS_FOLDER/$(basename "$directory").log"
             echo "Broken symlinks in $directory:" > "$log_file"
-            find . -type l -xtype l -exec ls -l {} + >> "$log_file"
+            printf '%s\0' "${broken_links[@]}" | xargs -0 ls -l >> "$log_file"
             echo "Broken symlinks in $directory have been logged to $log_file."
 
             # Delete broken symlinks
-            find . -type l -xtype l -delete
+            printf '%s\0' "${broken_links[@]}" | xargs -0 rm -f
         else
             echo "No broken symlinks found in $directory."
         
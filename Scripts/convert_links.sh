@@ -0,0 +1,69 @@
+#!/bin/bash
+
+# Convert the library in DESTINATION_DIR between link modes: video symlinks become .strm files holding the same
+# target (strm), or .strm files become symlinks again (symlink). The mode defaults to LINK_MODE from .env
+
+# Get the directory of the script
+SCRIPT_DIR=$(dirname "$(realpath "$0" | sed 's/\\/\//g')")
+ENV_FILE="$SCRIPT_DIR/../.env"
+
+if [ -f "$ENV_FILE" ]; then
+    source "$SCRIPT_DIR/load_env.sh"
+    load_env_file "$ENV_FILE"
+else
+    echo "Error: .env file not found in the parent directory."
+    exit 1
+fi
+
+destination_dir="$DESTINATION_DIR"
+target_mode="${1:-${LINK_MODE:-symlink}}"
+
+if [[ -z "$destination_dir" || ! -d "$destination_dir" ]]; then
+    echo "Error: Destination directory '$destination_dir' does not exist."
+    exit 1
+fi
+
+converted=0
+skipped=0
+
+case "$target_mode" in
+    "strm")
+        # Replace video symlinks with .strm files holding the same (already translated) target
+        while IFS= read -r -d '' link && IFS= read -r -d '' target; do
+            strm_file="${link%.*}.strm"
+            if [ -e "$strm_file" ]; then
+                echo "Skipping $link: $strm_file already exists."
+                skipped=$((skipped + 1))
+                continue
+            fi
+            echo "$target" > "$strm_file" && rm -f "$link"
+            converted=$((converted + 1))
+        done < <(find "$destination_dir" -type l \( -iname "*.mkv" -o -iname "*.mp4" \) -printf '%p\0%l\0')
+        ;;
+    "symlink")
+        # Replace .strm files with symlinks to the path they hold, keeping the source file's extension
+        while IFS= read -r -d '' strm_file; do
+            IFS= read -r target < "$strm_file"
+            target="${target%$'\r'}"
+            if [[ -z "$target" || "$target" == *"://"* || "${target##*/}" != *.* ]]; then
+                echo "Skipping $strm_file: it does not hold a file path."
+                skipped=$((skipped + 1))
+                continue
+            fi
+            link="${strm_file%.strm}.${target##*.}"
+            if [[ -e "$link" || -L "$link" ]]; then
+                echo "Skipping $strm_file: $link already exists."
+                skipped=$((skipped + 1))
+                continue
+            fi
+            ln -s "$target" "$link" && rm -f "$strm_file"
+            converted=$((converted + 1))
+        done < <(find "$destination_dir" -type f -name "*.strm" -print0)
+        ;;
+    *)
+        echo "Usage: $0 [symlink|strm]"
+        exit 1
+        ;;
+esac
+
+echo "Converted $converted entries in $destination_dir to $target_mode mode ($skipped skipped)."
@@ -10,7 +10,8 @@ parent_dir="$(dirname "$(dirname "$0")")"
 env_file="$parent_dir/.env"
 if [ -f "$env_file" ]; then
     # Load environment variables, ignoring comments and empty lines
-    export $(grep -v '^#' "$env_file" | grep -v '^$' | xargs)
+    source "$(dirname "$0")/load_env.sh"
+    load_env_file "$env_file"
 else
     echo "Error: .env file not found in the parent directory."
     exit 1
@@ -102,6 +103,48 @@ log_message "Parsed source directories: ${SOURCE_DIRS[*]}" "DEBUG" "stdout"
 destination_dir="$DESTINATION_DIR"
 log_message "Destination directory: $destination_dir" "DEBUG" "stdout"
 
+# Path translation rules for symlink targets
+symlink_path_map="$SYMLINK_PATH_MAP"
+log_message "Symlink path map: $symlink_path_map" "DEBUG" "stdout"
+source "$(dirname "$0")/path_translation.sh"
+
+# Season folder naming pattern (printf style, "none" for flat series folders)
+season_folder_format="${SEASON_FOLDER_FORMAT:-Season %02d}"
+log_message "Season folder format: $season_folder_format" "DEBUG" "stdout"
+
+# The format must hold exactly one number conversion (%% is a literal percent sign): without one every season
+# would merge into one folder, and extra conversions make printf fail
+season_folder_conversions="${season_folder_format//%%/}"
+if [[ "$season_folder_format" != "none" && ! "$season_folder_conversions" =~ ^[^%]*%[-0-9]*[di][^%]*$ ]]; then
+    log_message "Error: SEASON_FOLDER_FORMAT '$season_folder_format' must contain exactly one %d conversion (e.g. \"Season %02d\") or be none." "ERROR" "stdout"
+    exit 1
+fi
+
+# Link mode: "symlink" (default) or "strm" to write .strm files instead of symlinks
+link_mode="${LINK_MODE:-symlink}"
+log_message "Link mode: $link_mode" "DEBUG" "stdout"
+
+# Ignore patterns managed from the CineSync menu (one shell glob, or a regex prefixed with re:, per line), loaded once for the whole run
+ignore_file="$parent_dir/ignore_patterns.txt"
+log_message "Ignore patterns file: $ignore_file" "DEBUG" "stdout"
+ignore_patterns=()
+if [ -f "$ignore_file" ]; then
+    while IFS= read -r pattern || [ -n "$pattern" ]; do
+        pattern="${pattern%$'\r'}"
+        if [[ -n "$pattern" && "$pattern" != "#"* ]]; then
+            ignore_patterns+=("$pattern")
+        fi
+    done < "$ignore_file"
+fi
+
+# Video files smaller than this many MB are ignored as samples or extras (0 disables the check)
+min_file_size="${MIN_FILE_SIZE:-0}"
+log_message "Minimum video file size: $min_file_size MB" "DEBUG" "stdout"
+if [[ ! "$min_file_size" =~ ^[0-9]+$ ]]; then
+    log_message "Error: MIN_FILE_SIZE '$min_file_size' must be a whole number of MB." "ERROR" "stdout"
+    exit 1
+fi
+
 # Log directory
 log_dir="logs"
 log_message "Log directory: $log_dir" "DEBUG" "stdout"
@@ -117,27 +160,129 @@ if [ ! -d "$destination_dir" ]; then
 	log_message "Destination directory '$destination_dir' created." "DEBUG" "stdout"
 fi
 
+# Function to build the season folder name for a season number using SEASON_FOLDER_FORMAT
+season_folder_name() {
+    local season="$1"
+    if [ "$season_folder_format" == "none" ]; then
+        echo ""
+        return
+    fi
+    echo "$season" | awk -v fmt="$season_folder_format" '{printf fmt, $1}'
+}
+
+# Function to check whether a file or folder matches an ignore pattern, or is a video file below MIN_FILE_SIZE
+is_ignored() {
+    local target="$1"
+    local name="${target##*/}"
+    local lower_name="${name,,}"
+    local pattern
+    local reason=""
+    local size
+
+    shopt -s nocasematch
+    for pattern in "${ignore_patterns[@]}"; do
+        if [[ "$pattern" == "re:"* ]]; then
+            if [[ "$name" =~ ${pattern#re:} ]]; then
+                reason="matches pattern '$pattern'"
+                break
+            fi
+        elif [[ "$name" == $pattern ]]; then
+            reason="matches pattern '$pattern'"
+            break
+        fi
+    done
+    shopt -u nocasematch
+
+    if [[ -z "$reason" && "$min_file_size" -gt 0 && -f "$target" && ( "$lower_name" == *.mkv || "$lower_name" == *.mp4 ) ]]; then
+        size=$(stat -L -c %s "$target" 2>/dev/null)
+        if [[ -n "$size" && "$size" -lt $((min_file_size * 1024 * 1024)) ]]; then
+            reason="smaller than MIN_FILE_SIZE of $min_file_size MB"
+        fi
+    fi
+
+    if [ -z "$reason" ]; then
+        return 1
+    fi
+
+    log_message "Ignoring $target ($reason)" "INFO" "stdout"
+    if ! grep -qFx "$target" "$log_dir/ignored_files.log" 2>/dev/null; then
+        echo "$target" >> "$log_dir/ignored_files.log"
+    fi
+    return 0
+}
+
+# Function to check whether a file is written as a .strm file, which only applies to the video types the movie scan uses
+is_strm_file() {
+    local file="${1,,}"
+    [[ "$link_mode" == "strm" && ( "$file" == *.mkv || "$file" == *.mp4 ) ]]
+}
+
+# Function to get the destination path for a media file in the configured link mode
+media_link_path() {
+    local destination_file="$1"
+    if is_strm_file "$destination_file"; then
+        echo "${destination_file%.*}.strm"
+    else
+        echo "$destination_file"
+    fi
+}
+
+# Function to link a source file into the destination as a .strm file for videos in strm mode, or as a symlink
+create_media_link() {
+    local source_file="$1"
+    local destination_file="$2"
+    translate_symlink_target "$source_file"
+    if is_strm_file "$source_file"; then
+        echo "$translated_path" > "$destination_file"
+    else
+        ln -s "$translated_path" "$destination_file"
+    fi
+}
+
 # Function to check all symlinks in the destination directory and save their target paths to appropriate log files
 check_symlinks_in_destination() {
     echo "Checking symlinks in destination directory..."
     if [[ "$os" == "MINGW"* || "$os" == "MSYS"* ]]; then
         # Handling for series.log
         while IFS= read -r symlink; do
-            target=$(readlink "$symlink")
-            windows_path=$(cygpath -w "$target" | sed 's/\\/\//g')
+            translate_symlink_target "$(readlink "$symlink")" "reverse"
+            windows_path=$(cygpath -w "$translated_path" | sed 's/\\/\//g')
             echo "$windows_path"
         done < <(find "$destination_dir" -type l) > "$log_dir/series.log"
 
         # Handling for movies.log
         while IFS= read -r symlink; do
-            target=$(readlink "$symlink")
-            windows_path=$(cygpath -w "$target" | sed 's/\\/\//g')
+            translate_symlink_target "$(readlink "$symlink")" "reverse"
+            windows_path=$(cygpath -w "$translated_path" | sed 's/\\/\//g')
             echo "$windows_path"
         done < <(find "$destination_dir" -type l) > "$log_dir/movies.log"
+    elif [ -n "$symlink_path_map" ]; then
+        # Rewritten targets do not resolve locally, so map them back instead of canonicalizing
+        local unresolved_links=()
+        while IFS= read -r -d '' symlink && IFS= read -r -d '' target; do
+            if translate_symlink_target "$target" "reverse"; then
+                echo "$translated_path"
+            else
+                unresolved_links+=("$symlink")
+            fi
+        done < <(find "$destination_dir" -type l -printf '%p\0%l\0') > "$log_dir/series.log"
+
+        # Resolve the remaining links in one batch
+        if [ ${#unresolved_links[@]} -gt 0 ]; then
+            printf '%s\0' "${unresolved_links[@]}" | xargs -0 readlink -f >> "$log_dir/series.log"
+        fi
+        cp "$log_dir/series.log" "$log_dir/movies.log"
     else
         find "$destination_dir" -type l -exec readlink -f {} + > "$log_dir/series.log"
         find "$destination_dir" -type l -exec readlink -f {} + > "$log_dir/movies.log"
     fi
+
+    # .strm files hold their source path as content
+    while IFS= read -r strm_file; do
+        IFS= read -r strm_target < "$strm_file"
+        translate_symlink_target "${strm_target%$'\r'}" "reverse"
+        echo "$translated_path"
+    done < <(find "$destination_dir" -type f -name "*.strm") | tee -a "$log_dir/series.log" >> "$log_dir/movies.log"
     echo "Symlinks in destination directory checked and saved to $log_dir/series.log and $log_dir/movies.log"
 }
 
@@ -197,6 +342,11 @@ organize_media_files() {
         base_folder_name=""
     fi
 
+    # Skip targets matching an ignore pattern
+    if is_ignored "$folder" || { [ -n "$target_file" ] && is_ignored "$folder/$target_file"; }; then
+        return 0
+    fi
+
     #Skip target if a RAR file is detected
     if [[ "${target_file}" =~ \.r[^/]*$ ]]; then
       log_message "Skipping RAR file: $target_file" "WARNING" "stdout"
@@ -311,14 +461,20 @@ organize_media_files() {
             destination_movie_dir="$destination_movie_dir ($movie_year)"
         fi
 
-        local movie_file=$(find "$folder" -maxdepth 1 \( -iname "*.mkv" -o -iname "*.mp4" \) -print -quit)
+        local movie_file=""
+        while IFS= read -r candidate; do
+            if ! is_ignored "$candidate"; then
+                movie_file="$candidate"
+                break
+            fi
+        done < <(find "$folder" -maxdepth 1 \( -iname "*.mkv" -o -iname "*.mp4" \))
 
         if [ -z "$movie_file" ]; then
             log_message "Error: No movie file (*.mkv or *.mp4) found in $folder." "ERROR" "stdout"
             return 1
         fi
 
-        local destination_file="$destination_movie_dir/$(basename "$movie_file")"
+        local destination_file=$(media_link_path "$destination_movie_dir/$(basename "$movie_file")")
 
         if grep -qF "$movie_file" "$log_dir/movies.log"; then
             log_message "A symlink already exists for $(basename "$movie_file") with the same target." "DEBUG" "stdout"
@@ -328,7 +484,7 @@ organize_media_files() {
         else
             mkdir -p "$destination_movie_dir"
             echo "$destination_movie_dir" >> "$movies_log"
-            ln -s "$movie_file" "$destination_file"
+            create_media_link "$movie_file" "$destination_file"
             log_message "Symlink created: $movie_file -> $destination_file" "DEBUG" "stdout"
             if [ "$RENAME_ENABLED" == "true" ]; then
                 $PYTHON_CMD tmdb_renamer.py "$destination_file"
@@ -367,17 +523,20 @@ organize_media_files() {
         if [ -z "$target_file" ]; then
             shopt -s nullglob
             for file in "$folder"/*; do
+                if is_ignored "$file"; then
+                    continue
+                fi
                 filename=$(basename "$file")
                 season_folders=()
                 series_season=""
 
                 if [[ $folder =~ \.S([0-9]{2})\. ]] || [[ $filename =~ [Ss]([0-9]+) ]] || [[ $folder =~ Season\.([0-9]+-[0-9]+) ]]; then
                     series_season="${BASH_REMATCH[1]}"
-                    season_folders+=("Season $(echo "$series_season" | awk '{printf "%02d", $1}')")
+                    season_folders+=("$(season_folder_name "$series_season")")
                 fi
 
                 for season_folder in "${season_folders[@]}"; do
-                    destination_file="$destination_series_dir/$season_folder/$filename"
+                    destination_file=$(media_link_path "$destination_series_dir/${season_folder:+$season_folder/}$filename")
 
                     if grep -qF "$file" "$log_dir/series.log"; then
                         log_message "Symlink already exists for $filename with the same target." "DEBUG" "stdout"
@@ -387,7 +546,7 @@ organize_media_files() {
                     else
                         log_message "No symlink exists with the same target." "DEBUG" "stdout"
                         mkdir -p "$(dirname "$destination_file")"
-                        ln -s "$file" "$destination_file"
+                        create_media_link "$file" "$destination_file"
                         if [ "$RENAME_ENABLED" == "true" ]; then
                             $PYTHON_CMD tmdb_renamer.py "$destination_file"
                         fi
@@ -407,8 +566,8 @@ organize_media_files() {
                 exit 1
             fi
 
-            season_folder="Season $(printf "%02d" "$series_season")"
-            destination_file="$destination_series_dir/$season_folder/$target_file"
+            season_folder=$(season_folder_name "$series_season")
+            destination_file=$(media_link_path "$destination_series_dir/${season_folder:+$season_folder/}$target_file")
 
             if grep -qF "$target_file" "$log_dir/series.log"; then
                 log_message "Symlink already exists for $target_file with the same target." "DEBUG" "stdout"
@@ -418,7 +577,7 @@ organize_media_files() {
             else
                 log_message "No symlink exists with the same target." "DEBUG" "stdout"
                 mkdir -p "$(dirname "$destination_file")"
-                ln -s "$folder/$target_file" "$destination_file"
+                create_media_link "$folder/$target_file" "$destination_file"
                 if [ "$RENAME_ENABLED" == "true" ]; then
                     $PYTHON_CMD tmdb_renamer.py "$destination_file"
                 fi
@@ -432,6 +591,9 @@ organize_media_files() {
 # Function to symlink a specific file or folder
 symlink_specific_file_or_folder() {
     local target="$1"
+    if is_ignored "$target"; then
+        return 0
+    fi
     if [[ "${target}" =~ \.r[^/]*$ ]]; then
       log_message "Skipping RAR file: $target" "WARNING" "stdout"
       if ! grep -qFx "$target" "$log_dir/skipped_rar_files.log"; then
@@ -442,11 +604,11 @@ symlink_specific_file_or_folder() {
 
     if [ -e "$target" ]; then
         local filename=$(basename "$target")
-        local destination_file="$destination_dir/$filename"
-        if [ -L "$destination_file" ]; then
+        local destination_file=$(media_link_path "$destination_dir/$filename")
+        if [[ -L "$destination_file" || ( "$destination_file" == *.strm && -f "$destination_file" ) ]]; then
             log_message "A symlink already exists for $filename in the destination directory." "DEBUG" "stdout"
         else
-            ln -s "$target" "$destination_file"
+            create_media_link "$target" "$destination_file"
             log_message "Symlink created: $target -> $destination_file" "DEBUG" "stdout"
         fi
     else
@@ -456,7 +618,7 @@ symlink_specific_file_or_folder() {
 
 cleanup() {
     log_message "Removing .r files from the destination directory..." "DEBUG" "stdout"
-    find "$destination_dir" -type f -name "*.r*" -exec rm {} +
+    find "$destination_dir" -type f -name "*.r*" ! -name "*.strm" -exec rm {} +
     log_message "All .r files removed from the destination directory." "DEBUG" "stdout"
 
     log_message "Removing empty directories from the destination directory..." "INFO" "stdout"
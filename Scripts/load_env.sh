@@ -0,0 +1,23 @@
+#!/bin/bash
+
+# Shared by library.sh and broken_links.sh to read the .env file in the parent directory
+
+# Function to export the variables of an .env file, ignoring comments and empty lines
+# Values may contain spaces, CRLF line endings are accepted and surrounding quotes are stripped
+load_env_file() {
+    local env_file="$1"
+    local line
+    local value
+
+    while IFS= read -r line || [ -n "$line" ]; do
+        line="${line%$'\r'}"
+        if [[ -z "$line" || "$line" == "#"* || "$line" != *"="* ]]; then
+            continue
+        fi
+        value="${line#*=}"
+        if [[ "$value" =~ ^\"(.*)\"$ || "$value" =~ ^\'(.*)\'$ ]]; then
+            value="${BASH_REMATCH[1]}"
+        fi
+        export "${line%%=*}=$value"
+    done < "$env_file"
+}
@@ -0,0 +1,55 @@
+#!/bin/bash
+
+# Shared by library.sh and broken_links.sh, which set symlink_path_map from SYMLINK_PATH_MAP before sourcing this file
+
+# Normalize the SYMLINK_PATH_MAP rules (from=>to, comma separated) once: forward slashes and no trailing slash
+symlink_map_from=()
+symlink_map_to=()
+if [ -n "$symlink_path_map" ]; then
+    IFS=',' read -ra symlink_map_rules <<< "$symlink_path_map"
+    for symlink_map_rule in "${symlink_map_rules[@]}"; do
+        if [[ "$symlink_map_rule" != *"=>"* ]]; then
+            continue
+        fi
+        symlink_map_rule_from="${symlink_map_rule%%=>*}"
+        symlink_map_rule_from="${symlink_map_rule_from//\\//}"
+        while [[ "$symlink_map_rule_from" == */ ]]; do
+            symlink_map_rule_from="${symlink_map_rule_from%/}"
+        done
+        symlink_map_rule_to="${symlink_map_rule#*=>}"
+        symlink_map_rule_to="${symlink_map_rule_to//\\//}"
+        while [[ "$symlink_map_rule_to" == */ ]]; do
+            symlink_map_rule_to="${symlink_map_rule_to%/}"
+        done
+        symlink_map_from+=("$symlink_map_rule_from")
+        symlink_map_to+=("$symlink_map_rule_to")
+    done
+fi
+
+# Function to rewrite a symlink target using the SYMLINK_PATH_MAP rules
+# Pass "reverse" as the second argument to map a rewritten target back to the local source path
+# The result is stored in translated_path, so callers don't need a subshell for every link
+# Returns 0 when a rule matched and 1 when the path was left unchanged
+translate_symlink_target() {
+    local path="$1"
+    local direction="$2"
+    local i
+    local from
+    local to
+
+    translated_path="$path"
+    for i in "${!symlink_map_from[@]}"; do
+        from="${symlink_map_from[$i]}"
+        to="${symlink_map_to[$i]}"
+        if [ "$direction" == "reverse" ]; then
+            from="${symlink_map_to[$i]}"
+            to="${symlink_map_from[$i]}"
+        fi
+        # Only match on a path boundary, so /mnt/zurg does not also rewrite /mnt/zurg2
+        if [[ -n "$from" && ( "$path" == "$from" || "$path" == "$from/"* ) ]]; then
+            translated_path="$to${path#"$from"}"
+            return 0
+        fi
+    done
+    return 1
+}
@@ -62,12 +62,16 @@ def get_movie_details(title_id, bearer_token):
         return movie_details.get('title'), movie_details.get('release_date')[:4]
     return None, None
 
-def query_tmdb(title_search, bearer_token, content_type):
+def query_tmdb(title_search, bearer_token, content_type, year=None):
     """ Query TMDB for the given title and return the show/movie name and ID """
     if content_type == 'episode':
         url = f"https://api.themoviedb.org/3/search/tv?query={title_search}"
+        if year:
+            url += f"&first_air_date_year={year}"
     else:
         url = f"https://api.themoviedb.org/3/search/movie?query={title_search}"
+        if year:
+            url += f"&year={year}"
 
     headers = {
         'Authorization': f'Bearer {bearer_token}',
@@ -75,8 +79,25 @@ def query_tmdb(title_search, bearer_token, content_type):
     }
     response = requests.get(url, headers=headers)
     if response.status_code == 200 and response.json()['results']:
-        first_result = response.json()['results'][0]
-        return first_result['id'], first_result['name'] if content_type == 'episode' else first_result['title']
+        results = response.json()['results']
+        title_key = 'name' if content_type == 'episode' else 'title'
+        date_key = 'first_air_date' if content_type == 'episode' else 'release_date'
+        best_result = results[0]
+        if year:
+            # Prefer the result released in the requested year, so remakes don't merge into the original
+            for result in results:
+                if (result.get(date_key) or '')[:4] == str(year):
+                    best_result = result
+                    break
+        else:
+            same_title = [r for r in results if r.get(title_key, '').lower() == best_result.get(title_key, '').lower()]
+            if len(same_title) > 1:
+                years = ', '.join((r.get(date_key) or '????')[:4] for r in same_title)
+                log_message(f"Multiple TMDB matches for '{title_search}' ({years}); using the first. Add the year to the filename to disambiguate.", "WARNING", "stdout")
+        return best_result['id'], best_result[title_key]
+    elif year:
+        # The guessed year may be off, so fall back to a search without it
+        return query_tmdb(title_search, bearer_token, content_type)
     else:
         return None, None
 
@@ -100,7 +121,7 @@ def process_file(filepath, bearer_token):
         title_search = guessed_info['title']
         season = guessed_info['season']
         episode = guessed_info['episode']
-        show_id, show_full_name = query_tmdb(title_search, bearer_token, content_type)
+        show_id, show_full_name = query_tmdb(title_search, bearer_token, content_type, guessed_info.get('year'))
         if show_id and show_full_name:
             episode_name = get_tv_episode_details(show_id, season, episode, bearer_token)
             if episode_name:
@@ -119,7 +140,7 @@ def process_file(filepath, bearer_token):
             log_message(f"No TMDB match found for {filename}", "ERROR", "stdout")
     elif content_type == 'movie':
         title_search = guessed_info['title']
-        movie_id, movie_title = query_tmdb(title_search, bearer_token, content_type)
+        movie_id, movie_title = query_tmdb(title_search, bearer_token, content_type, guessed_info.get('year'))
         if movie_id and movie_title:
             release_year = guessed_info.get('year') or get_movie_details(movie_id, bearer_token)[1]
             part = guessed_info.get('part')